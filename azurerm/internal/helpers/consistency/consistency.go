@@ -0,0 +1,150 @@
+// Package consistency centralises the "wait until the API settles down" dance that a handful of
+// resources need after a write: Azure sometimes serves a stale or flapping read immediately after
+// a PUT/DELETE, so a single read-back isn't enough to trust the result. Resources that need this
+// should prefer WaitForUpdate/WaitForDeletion here over hand-rolling their own
+// resource.StateChangeConf, so the backoff/jitter/stability behaviour stays consistent across the
+// provider - this mirrors how the azuread provider centralises the same problem.
+package consistency
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const (
+	// DefaultContinuousTargetOccurence is the number of consecutive matching reads required
+	// before a refresh is trusted, chosen to absorb the kind of 404->200 (or stale-data) flap
+	// seen on role definition and role assignment propagation.
+	DefaultContinuousTargetOccurence = 5
+
+	defaultDelay      = 10 * time.Second
+	defaultMinTimeout = 10 * time.Second
+	defaultJitter     = 3 * time.Second
+)
+
+// Options controls how a wait loop behaves. The zero value is not valid - use DefaultOptions
+// and override individual fields as needed.
+type Options struct {
+	// ContinuousTargetOccurence is how many consecutive refreshes must agree before the wait
+	// succeeds.
+	ContinuousTargetOccurence int
+
+	// Delay is the initial wait before the first refresh; a small random jitter is added to it,
+	// and to MinTimeout below, so that many resources waiting in parallel don't all poll Azure in
+	// lockstep.
+	Delay time.Duration
+
+	// MinTimeout is the minimum wait between subsequent refreshes - this is what actually paces
+	// steady-state polling, since Delay only applies once at the start.
+	MinTimeout time.Duration
+}
+
+// DefaultOptions returns the options used by the role definition resource prior to this package's
+// introduction, and is a reasonable default for most eventual-consistency waits in this provider.
+func DefaultOptions() Options {
+	return Options{
+		ContinuousTargetOccurence: DefaultContinuousTargetOccurence,
+		Delay:                     defaultDelay,
+		MinTimeout:                defaultMinTimeout,
+	}
+}
+
+// UpdateRefreshFunc reports the current state of an in-flight write: found indicates whether the
+// resource could be read at all (false covers both a genuine 404 and a transient read error worth
+// retrying), and stable indicates whether the read matches what's expected post-write. Returning
+// a non-nil err aborts the wait immediately.
+type UpdateRefreshFunc func() (found bool, stable bool, err error)
+
+// DeleteRefreshFunc reports whether the resource still exists. Deletion waits require the resource
+// to be reported gone ContinuousTargetOccurence times in a row, so a single 404 served while the
+// delete is still propagating doesn't get mistaken for the delete having completed.
+type DeleteRefreshFunc func() (found bool, err error)
+
+// WaitForCreate blocks until refresh reports a stable result ContinuousTargetOccurence times in a
+// row, or the resource's Create timeout elapses. Identical to WaitForUpdate bar the timeout it
+// reads from d, split out so a Create's wait doesn't borrow the (possibly different) Update budget.
+func WaitForCreate(ctx context.Context, d *schema.ResourceData, opts Options, refresh UpdateRefreshFunc) error {
+	return wait(ctx, opts, d.Timeout(schema.TimeoutCreate), func() (interface{}, string, error) {
+		found, stable, err := refresh()
+		if err != nil {
+			return nil, "", err
+		}
+		if !found {
+			return "pending", "Pending", nil
+		}
+		if !stable {
+			return "pending", "Pending", nil
+		}
+		return "stable", "Stable", nil
+	}, "Pending", "Stable")
+}
+
+// WaitForUpdate blocks until refresh reports a stable result ContinuousTargetOccurence times in a
+// row, or the resource's Update timeout elapses.
+func WaitForUpdate(ctx context.Context, d *schema.ResourceData, opts Options, refresh UpdateRefreshFunc) error {
+	return wait(ctx, opts, d.Timeout(schema.TimeoutUpdate), func() (interface{}, string, error) {
+		found, stable, err := refresh()
+		if err != nil {
+			return nil, "", err
+		}
+		if !found {
+			return "pending", "Pending", nil
+		}
+		if !stable {
+			return "pending", "Pending", nil
+		}
+		return "stable", "Stable", nil
+	}, "Pending", "Stable")
+}
+
+// WaitForDeletion blocks until refresh reports the resource gone ContinuousTargetOccurence times
+// in a row, or the resource's Delete timeout elapses.
+func WaitForDeletion(ctx context.Context, d *schema.ResourceData, refresh DeleteRefreshFunc) error {
+	opts := DefaultOptions()
+	return wait(ctx, opts, d.Timeout(schema.TimeoutDelete), func() (interface{}, string, error) {
+		found, err := refresh()
+		if err != nil {
+			return nil, "", err
+		}
+		if found {
+			return "found", "Found", nil
+		}
+		return "gone", "Gone", nil
+	}, "Found", "Gone")
+}
+
+func wait(ctx context.Context, opts Options, timeout time.Duration, refresh resource.StateRefreshFunc, pending, target string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		ContinuousTargetOccurence: opts.ContinuousTargetOccurence,
+		Delay:                     jitter(opts.Delay),
+		// MinTimeout is what actually paces the poll interval between refreshes (Delay only
+		// applies once, before the first one) - jitter it too, or "jitter is added to each tick"
+		// isn't true and resources waiting in parallel still end up polling in lockstep.
+		MinTimeout: jitter(opts.MinTimeout),
+		Pending:    []string{pending},
+		Target:     []string{target},
+		Refresh:    refresh,
+		Timeout:    timeout,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("waiting for resource to settle down: %+v", err)
+	}
+
+	return nil
+}
+
+func jitter(base time.Duration) time.Duration {
+	if base <= 0 {
+		return base
+	}
+	return base + time.Duration(rand.Int63n(int64(defaultJitter)))
+}
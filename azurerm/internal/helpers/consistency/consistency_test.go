@@ -0,0 +1,20 @@
+package consistency
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJitter(t *testing.T) {
+	if got := jitter(0); got != 0 {
+		t.Errorf("jitter(0) = %v, want 0", got)
+	}
+
+	base := 10 * time.Second
+	for i := 0; i < 100; i++ {
+		got := jitter(base)
+		if got < base || got >= base+defaultJitter {
+			t.Fatalf("jitter(%v) = %v, want a value in [%v, %v)", base, got, base, base+defaultJitter)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/authorization/mgmt/2020-10-01/authorization"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/authorization/parse"
+	azSchema "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// resourceArmRoleAssignmentScheduleRequest manages the PIM "active" assignment request
+// counterpart to resourceArmRoleEligibilityScheduleRequest - see that resource for the rationale
+// behind using AdminUpdate/AdminRemove requests rather than Cancel for Update/Delete.
+func resourceArmRoleAssignmentScheduleRequest() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmRoleAssignmentScheduleRequestCreate,
+		Read:   resourceArmRoleAssignmentScheduleRequestRead,
+		Update: resourceArmRoleAssignmentScheduleRequestUpdate,
+		Delete: resourceArmRoleAssignmentScheduleRequestDelete,
+
+		Importer: azSchema.ValidateResourceIDPriorToImport(func(id string) error {
+			_, err := parse.RoleAssignmentScheduleRequestID(id)
+			return err
+		}),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// not Optional: Update mints a fresh request name on every call (see
+			// resourceArmRoleAssignmentScheduleRequestUpdate), so a user-supplied value would be
+			// silently discarded and permanently diff against the ForceNew field from then on.
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"scope": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// ForceNew: Update's `AdminUpdate` re-request is resolved by Azure against
+			// `scope`/`role_definition_id`/`principal_id`, so changing either of those isn't an update
+			// to the existing assignment at all - it's a grant to a different principal/role, leaving
+			// the original ungoverned by Terraform. Force a destroy (which revokes via `AdminRemove`)
+			// and recreate instead.
+			"role_definition_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"principal_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"justification": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"schedule_info": schemaScheduleInfo(),
+		},
+	}
+}
+
+func resourceArmRoleAssignmentScheduleRequestCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Authorization.RoleAssignmentScheduleRequestsClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	name := d.Get("name").(string)
+	scope := d.Get("scope").(string)
+
+	if name == "" {
+		generated, err := uuid.GenerateUUID()
+		if err != nil {
+			return fmt.Errorf("generating UUID for Role Assignment Schedule Request: %+v", err)
+		}
+		name = generated
+	}
+
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, scope, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing Role Assignment Schedule Request %q (Scope %q): %+v", name, scope, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_role_assignment_schedule_request", *existing.ID)
+		}
+	}
+
+	properties := authorization.RoleAssignmentScheduleRequest{
+		RoleAssignmentScheduleRequestProperties: expandRoleAssignmentScheduleRequestProperties(d),
+	}
+
+	if _, err := client.Create(ctx, scope, name, properties); err != nil {
+		return fmt.Errorf("creating Role Assignment Schedule Request %q (Scope %q): %+v", name, scope, err)
+	}
+
+	d.SetId(parse.NewRoleAssignmentScheduleRequestID(scope, name).ID())
+	return resourceArmRoleAssignmentScheduleRequestRead(d, meta)
+}
+
+func resourceArmRoleAssignmentScheduleRequestUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Authorization.RoleAssignmentScheduleRequestsClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.RoleAssignmentScheduleRequestID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	generated, err := uuid.GenerateUUID()
+	if err != nil {
+		return fmt.Errorf("generating UUID for Role Assignment Schedule Request: %+v", err)
+	}
+
+	// `Cancel` only succeeds against a still-pending request - by the time Terraform sees drift
+	// the original request has long since been auto-approved and provisioned, so `Cancel` would
+	// just error out. Submit an `AdminUpdate` request instead: Azure resolves the assignment to
+	// update from `scope`/`role_definition_id`/`principal_id` rather than needing it cancelled first.
+	log.Printf("[DEBUG] Submitting an AdminUpdate Role Assignment Schedule Request (Scope %q) to update assignment", id.Scope)
+	properties := authorization.RoleAssignmentScheduleRequest{
+		RoleAssignmentScheduleRequestProperties: expandRoleAssignmentScheduleRequestProperties(d),
+	}
+	properties.RoleAssignmentScheduleRequestProperties.RequestType = authorization.AdminUpdate
+
+	if _, err := client.Create(ctx, id.Scope, generated, properties); err != nil {
+		return fmt.Errorf("updating Role Assignment Schedule Request (Scope %q): %+v", id.Scope, err)
+	}
+
+	d.SetId(parse.NewRoleAssignmentScheduleRequestID(id.Scope, generated).ID())
+	return resourceArmRoleAssignmentScheduleRequestRead(d, meta)
+}
+
+func resourceArmRoleAssignmentScheduleRequestRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Authorization.RoleAssignmentScheduleRequestsClient
+	schedulesClient := meta.(*clients.Client).Authorization.RoleAssignmentSchedulesClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.RoleAssignmentScheduleRequestID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	d.Set("scope", id.Scope)
+	d.Set("name", id.Name)
+
+	resp, err := client.Get(ctx, id.Scope, id.Name)
+	if err != nil {
+		if !utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("loading Role Assignment Schedule Request %q (Scope %q): %+v", id.Name, id.Scope, err)
+		}
+
+		// as with eligibility requests, the request object is GC'd after it's actioned, and the
+		// schedule it produced has its own (different) name - look it up by principal/role
+		// definition instead of by the request's name.
+		log.Printf("[DEBUG] Role Assignment Schedule Request %q (Scope %q) was not found - checking the active schedule instead", id.Name, id.Scope)
+		schedule, err := findRoleAssignmentSchedule(ctx, schedulesClient, id.Scope, d.Get("principal_id").(string), d.Get("role_definition_id").(string))
+		if err != nil {
+			return fmt.Errorf("finding active Role Assignment Schedule (Scope %q): %+v", id.Scope, err)
+		}
+		if schedule == nil {
+			log.Printf("[DEBUG] no active Role Assignment Schedule found (Scope %q) - removing from state", id.Scope)
+			d.SetId("")
+			return nil
+		}
+
+		if props := schedule.RoleAssignmentScheduleProperties; props != nil {
+			d.Set("role_definition_id", props.RoleDefinitionID)
+			d.Set("principal_id", props.PrincipalID)
+		}
+
+		return nil
+	}
+
+	if props := resp.RoleAssignmentScheduleRequestProperties; props != nil {
+		d.Set("role_definition_id", props.RoleDefinitionID)
+		d.Set("principal_id", props.PrincipalID)
+		d.Set("justification", props.Justification)
+
+		if err := d.Set("schedule_info", flattenScheduleInfo(flattenRoleAssignmentScheduleRequestScheduleInfo(props.ScheduleInfo))); err != nil {
+			return fmt.Errorf("setting `schedule_info`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceArmRoleAssignmentScheduleRequestDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Authorization.RoleAssignmentScheduleRequestsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.RoleAssignmentScheduleRequestID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	generated, err := uuid.GenerateUUID()
+	if err != nil {
+		return fmt.Errorf("generating UUID for Role Assignment Schedule Request: %+v", err)
+	}
+
+	// as with Update, `Cancel` only works against a still-pending request - once the assignment
+	// has been provisioned the only way to revoke it is to submit a new `AdminRemove` request.
+	properties := authorization.RoleAssignmentScheduleRequest{
+		RoleAssignmentScheduleRequestProperties: &authorization.RoleAssignmentScheduleRequestProperties{
+			RoleDefinitionID: utils.String(d.Get("role_definition_id").(string)),
+			PrincipalID:      utils.String(d.Get("principal_id").(string)),
+			RequestType:      authorization.AdminRemove,
+		},
+	}
+
+	if _, err := client.Create(ctx, id.Scope, generated, properties); err != nil {
+		return fmt.Errorf("removing Role Assignment Schedule Request %q (Scope %q): %+v", id.Name, id.Scope, err)
+	}
+
+	return nil
+}
+
+func expandRoleAssignmentScheduleRequestProperties(d *schema.ResourceData) *authorization.RoleAssignmentScheduleRequestProperties {
+	info := expandScheduleInfo(d.Get("schedule_info").([]interface{}))
+
+	props := &authorization.RoleAssignmentScheduleRequestProperties{
+		RoleDefinitionID: utils.String(d.Get("role_definition_id").(string)),
+		PrincipalID:      utils.String(d.Get("principal_id").(string)),
+		RequestType:      authorization.AdminAssign,
+		Justification:    utils.String(d.Get("justification").(string)),
+	}
+
+	if info != nil {
+		scheduleInfo := &authorization.RoleAssignmentScheduleRequestPropertiesScheduleInfo{
+			StartDateTime: &info.StartDateTime,
+		}
+
+		if info.HasExpiration {
+			expiration := &authorization.RoleAssignmentScheduleRequestPropertiesScheduleInfoExpiration{
+				Type: authorization.Type1(info.ExpirationType),
+			}
+
+			// only send the field that applies to the chosen expiration type - the PIM API
+			// rejects e.g. a `duration` alongside `AfterDateTime`/`NoExpiration`.
+			switch info.ExpirationType {
+			case "AfterDuration":
+				expiration.Duration = utils.String(info.Duration)
+			case "AfterDateTime":
+				expiration.EndDateTime = utils.String(info.EndDateTime)
+			}
+
+			scheduleInfo.Expiration = expiration
+		}
+
+		props.ScheduleInfo = scheduleInfo
+	}
+
+	return props
+}
+
+// findRoleAssignmentSchedule locates the active schedule a request produced once the request
+// object itself has been pruned - see findRoleEligibilitySchedule for why this can't be a
+// Get-by-request-name lookup.
+func findRoleAssignmentSchedule(ctx context.Context, client authorization.RoleAssignmentSchedulesClient, scope, principalID, roleDefinitionID string) (*authorization.RoleAssignmentSchedule, error) {
+	filter := fmt.Sprintf("principalId eq '%s' and roleDefinitionId eq '%s'", principalID, roleDefinitionID)
+
+	schedules, err := client.ListForScope(ctx, scope, filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing Role Assignment Schedules (Scope %q): %+v", scope, err)
+	}
+
+	for _, schedule := range schedules.Values() {
+		schedule := schedule
+		props := schedule.RoleAssignmentScheduleProperties
+		if props == nil || props.PrincipalID == nil || props.RoleDefinitionID == nil {
+			continue
+		}
+		if *props.PrincipalID == principalID && *props.RoleDefinitionID == roleDefinitionID {
+			return &schedule, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func flattenRoleAssignmentScheduleRequestScheduleInfo(input *authorization.RoleAssignmentScheduleRequestPropertiesScheduleInfo) *scheduleInfo {
+	if input == nil {
+		return nil
+	}
+
+	out := &scheduleInfo{}
+	if input.StartDateTime != nil {
+		out.StartDateTime = *input.StartDateTime
+	}
+	if exp := input.Expiration; exp != nil {
+		out.HasExpiration = true
+		out.ExpirationType = string(exp.Type)
+		if exp.Duration != nil {
+			out.Duration = *exp.Duration
+		}
+		if exp.EndDateTime != nil {
+			out.EndDateTime = *exp.EndDateTime
+		}
+	}
+
+	return out
+}
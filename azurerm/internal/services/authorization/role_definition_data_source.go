@@ -0,0 +1,158 @@
+package authorization
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// dataSourceArmRoleDefinition looks up a built-in or custom role so its `permissions` can be
+// composed via `inherits_from` on the `azurerm_role_definition` resource, without the caller
+// having to hand-copy the hundreds of action strings a role like Contributor carries.
+func dataSourceArmRoleDefinition() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmRoleDefinitionRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "role_definition_id"},
+			},
+
+			"role_definition_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "role_definition_id"},
+			},
+
+			"scope": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"permissions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"actions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"not_actions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"data_actions": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"not_data_actions": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+
+			"assignable_scopes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmRoleDefinitionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Authorization.RoleDefinitionsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	scope := d.Get("scope").(string)
+	if scope == "" {
+		scope = fmt.Sprintf("/subscriptions/%s", meta.(*clients.Client).Account.SubscriptionId)
+	}
+
+	var roleDefinitionId string
+	if v, ok := d.GetOk("role_definition_id"); ok {
+		roleDefinitionId = v.(string)
+	} else {
+		name := d.Get("name").(string)
+		roleDefinitions, err := client.List(ctx, scope, fmt.Sprintf("roleName eq '%s'", name))
+		if err != nil {
+			return fmt.Errorf("loading Role Definition List for %q (Scope %q): %+v", name, scope, err)
+		}
+		if roleDefinitions.Values() == nil || len(roleDefinitions.Values()) == 0 {
+			return fmt.Errorf("could not find Role Definition with name %q at Scope %q", name, scope)
+		}
+		if len(roleDefinitions.Values()) > 1 {
+			return fmt.Errorf("found multiple Role Definitions with name %q at Scope %q", name, scope)
+		}
+		if id := roleDefinitions.Values()[0].ID; id != nil {
+			roleDefinitionId = *id
+		}
+	}
+
+	resp, err := client.GetByID(ctx, roleDefinitionId)
+	if err != nil {
+		return fmt.Errorf("loading Role Definition %q: %+v", roleDefinitionId, err)
+	}
+	if resp.ID == nil || *resp.ID == "" {
+		return fmt.Errorf("Role Definition %q returned an empty id", roleDefinitionId)
+	}
+
+	d.SetId(*resp.ID)
+	d.Set("role_definition_id", resp.ID)
+
+	if props := resp.RoleDefinitionProperties; props != nil {
+		d.Set("name", props.RoleName)
+		d.Set("description", props.Description)
+		d.Set("type", props.RoleType)
+
+		if err := d.Set("permissions", flattenRoleDefinitionPermissions(props.Permissions)); err != nil {
+			return fmt.Errorf("setting `permissions`: %+v", err)
+		}
+
+		if err := d.Set("assignable_scopes", flattenRoleDefinitionAssignableScopes(props.AssignableScopes)); err != nil {
+			return fmt.Errorf("setting `assignable_scopes`: %+v", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// roleDefinitionsClient is satisfied by the plain SDK client, which both Create and Update pass to
+// resolveRoleDefinitionPermissions, and by the fake client in the tests.
+//
+// inherits_from is documented to take the `azurerm_role_definition` data source's
+// `role_definition_id` output, which is the full `/subscriptions/.../roleDefinitions/{guid}`
+// resource path (see role_definition_data_source.go's own GetByID lookup), not a bare GUID -
+// so resolution here must go through GetByID rather than Get, which expects a GUID scoped
+// under a separate `scope` argument.
+type roleDefinitionsClient interface {
+	GetByID(ctx context.Context, roleID string) (authorization.RoleDefinition, error)
+}
+
+// resolveRoleDefinitionPermissions unions the `permissions` the user authored with the actions
+// inherited from `inherits_from`, then subtracts anything listed in `excluded_permissions`. When
+// neither is set it's returned unchanged, so this is a no-op for the vast majority of existing
+// configurations. `excluded_permissions` applies even without `inherits_from` - e.g. "Owner minus
+// a few actions" authored directly in `permissions` - so the exclusion must not be skipped just
+// because there's nothing to inherit.
+func resolveRoleDefinitionPermissions(ctx context.Context, client roleDefinitionsClient, d *schema.ResourceData, permissions []authorization.Permission) ([]authorization.Permission, error) {
+	inheritsFrom := d.Get("inherits_from").([]interface{})
+	excludedRaw := d.Get("excluded_permissions").([]interface{})
+	if len(inheritsFrom) == 0 && len(excludedRaw) == 0 {
+		return permissions, nil
+	}
+
+	set := newPermissionSet()
+	set.add(permissions)
+
+	for _, raw := range inheritsFrom {
+		if raw == nil || raw.(string) == "" {
+			continue
+		}
+		roleID := raw.(string)
+
+		inherited, err := client.GetByID(ctx, roleID)
+		if err != nil {
+			return nil, fmt.Errorf("loading inherited Role Definition %q: %+v", roleID, err)
+		}
+		if inherited.RoleDefinitionProperties == nil {
+			continue
+		}
+
+		set.add(derefPermissions(inherited.RoleDefinitionProperties.Permissions))
+	}
+
+	set.subtract(expandExcludedPermissions(excludedRaw))
+
+	return set.toPermissions(), nil
+}
+
+func derefPermissions(input *[]authorization.Permission) []authorization.Permission {
+	if input == nil {
+		return nil
+	}
+	return *input
+}
+
+// permissionSet deduplicates the four action lists that make up a role definition's permissions
+// across any number of source roles. Wildcards (`*`) are never expanded - they're tracked as an
+// opaque string like any other action, per the RBAC semantics.
+type permissionSet struct {
+	actions        map[string]struct{}
+	notActions     map[string]struct{}
+	dataActions    map[string]struct{}
+	notDataActions map[string]struct{}
+}
+
+func newPermissionSet() *permissionSet {
+	return &permissionSet{
+		actions:        map[string]struct{}{},
+		notActions:     map[string]struct{}{},
+		dataActions:    map[string]struct{}{},
+		notDataActions: map[string]struct{}{},
+	}
+}
+
+func (s *permissionSet) add(permissions []authorization.Permission) {
+	for _, permission := range permissions {
+		addAll(s.actions, permission.Actions)
+		addAll(s.notActions, permission.NotActions)
+		addAll(s.dataActions, permission.DataActions)
+		addAll(s.notDataActions, permission.NotDataActions)
+	}
+}
+
+func (s *permissionSet) subtract(excluded *permissionSet) {
+	if excluded == nil {
+		return
+	}
+	removeAll(s.actions, excluded.actions)
+	removeAll(s.dataActions, excluded.dataActions)
+}
+
+func (s *permissionSet) toPermissions() []authorization.Permission {
+	return []authorization.Permission{
+		{
+			Actions:        setToSlice(s.actions),
+			NotActions:     setToSlice(s.notActions),
+			DataActions:    setToSlice(s.dataActions),
+			NotDataActions: setToSlice(s.notDataActions),
+		},
+	}
+}
+
+func expandExcludedPermissions(input []interface{}) *permissionSet {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	set := newPermissionSet()
+
+	for _, a := range raw["actions"].([]interface{}) {
+		if a != nil {
+			set.actions[a.(string)] = struct{}{}
+		}
+	}
+	for _, a := range raw["data_actions"].(*schema.Set).List() {
+		if a != nil {
+			set.dataActions[a.(string)] = struct{}{}
+		}
+	}
+
+	return set
+}
+
+func addAll(dest map[string]struct{}, input *[]string) {
+	if input == nil {
+		return
+	}
+	for _, v := range *input {
+		dest[v] = struct{}{}
+	}
+}
+
+func removeAll(dest, excluded map[string]struct{}) {
+	for v := range excluded {
+		delete(dest, v)
+	}
+}
+
+func setToSlice(input map[string]struct{}) *[]string {
+	output := make([]string, 0, len(input))
+	for v := range input {
+		output = append(output, v)
+	}
+	return &output
+}
@@ -0,0 +1,106 @@
+package authorization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+type fakeRoleDefinitionsClient map[string]authorization.RoleDefinition
+
+func (f fakeRoleDefinitionsClient) GetByID(_ context.Context, roleID string) (authorization.RoleDefinition, error) {
+	return f[roleID], nil
+}
+
+func testResolvePermissionsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"inherits_from": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"excluded_permissions": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"actions":      {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+					"data_actions": {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}, Set: schema.HashString},
+				},
+			},
+		},
+	}
+}
+
+func TestResolveRoleDefinitionPermissions_inheritsFrom(t *testing.T) {
+	contributorId := "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/roleDefinitions/b24988ac-6180-42a0-ab88-20f7382dd24c"
+	client := fakeRoleDefinitionsClient{
+		contributorId: {
+			RoleDefinitionProperties: &authorization.RoleDefinitionProperties{
+				Permissions: &[]authorization.Permission{
+					{Actions: &[]string{"Microsoft.Resources/subscriptions/resourceGroups/read"}},
+				},
+			},
+		},
+	}
+
+	raw := map[string]interface{}{
+		"inherits_from": []interface{}{contributorId},
+	}
+	d := schema.TestResourceDataRaw(t, testResolvePermissionsSchema(), raw)
+
+	permissions := []authorization.Permission{
+		{Actions: &[]string{"Microsoft.Compute/virtualMachines/read"}},
+	}
+
+	got, err := resolveRoleDefinitionPermissions(context.Background(), client, d, permissions)
+	if err != nil {
+		t.Fatalf("resolveRoleDefinitionPermissions: %+v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected a single merged Permission, got %d", len(got))
+	}
+
+	actions := *got[0].Actions
+	if len(actions) != 2 {
+		t.Fatalf("expected the authored and inherited actions to be unioned, got %v", actions)
+	}
+}
+
+func TestResolveRoleDefinitionPermissions_excludedWithoutInheritsFrom(t *testing.T) {
+	raw := map[string]interface{}{
+		"excluded_permissions": []interface{}{
+			map[string]interface{}{
+				"actions": []interface{}{"Microsoft.Resources/subscriptions/resourceGroups/delete"},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, testResolvePermissionsSchema(), raw)
+
+	permissions := []authorization.Permission{
+		{
+			Actions: &[]string{
+				"Microsoft.Resources/subscriptions/resourceGroups/read",
+				"Microsoft.Resources/subscriptions/resourceGroups/delete",
+			},
+		},
+	}
+
+	got, err := resolveRoleDefinitionPermissions(context.Background(), fakeRoleDefinitionsClient{}, d, permissions)
+	if err != nil {
+		t.Fatalf("resolveRoleDefinitionPermissions: %+v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected a single merged Permission, got %d", len(got))
+	}
+
+	actions := *got[0].Actions
+	for _, a := range actions {
+		if a == "Microsoft.Resources/subscriptions/resourceGroups/delete" {
+			t.Fatalf("excluded action %q was not subtracted: %v", a, actions)
+		}
+	}
+}
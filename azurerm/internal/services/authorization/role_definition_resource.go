@@ -8,10 +8,10 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
 	"github.com/hashicorp/go-uuid"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/helpers/consistency"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/authorization/azuresdkhacks"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/authorization/parse"
 	azSchema "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/schema"
@@ -111,6 +111,39 @@ func resourceArmRoleDefinition() *schema.Resource {
 				},
 			},
 
+			"inherits_from": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"excluded_permissions": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"actions": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"data_actions": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Set: schema.HashString,
+						},
+					},
+				},
+			},
+
 			"assignable_scopes": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -120,6 +153,20 @@ func resourceArmRoleDefinition() *schema.Resource {
 				},
 			},
 
+			"expand_assignable_scopes": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"assignable_scopes_resolved": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
 			"role_definition_resource_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -150,7 +197,14 @@ func resourceArmRoleDefinitionCreate(d *schema.ResourceData, meta interface{}) e
 
 	permissionsRaw := d.Get("permissions").([]interface{})
 	permissions := expandRoleDefinitionPermissions(permissionsRaw)
-	assignableScopes := expandRoleDefinitionAssignableScopes(d)
+	permissions, err := resolveRoleDefinitionPermissions(ctx, client, d, permissions)
+	if err != nil {
+		return fmt.Errorf("resolving `inherits_from`/`excluded_permissions` for Role Definition %q (Scope %q): %+v", name, scope, err)
+	}
+	assignableScopes, err := expandRoleDefinitionAssignableScopes(ctx, meta, d)
+	if err != nil {
+		return fmt.Errorf("expanding `assignable_scopes` for Role Definition %q (Scope %q): %+v", name, scope, err)
+	}
 
 	if d.IsNewResource() {
 		existing, err := client.Get(ctx, scope, roleDefinitionId)
@@ -180,6 +234,13 @@ func resourceArmRoleDefinitionCreate(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 
+	// as with Update, a freshly-created role definition can serve a stale/404 read for a few
+	// seconds before it's propagated - wait until it reads back consistently before persisting it.
+	log.Printf("[DEBUG] Waiting for Role Definition %q (Scope %q) to settle down..", roleDefinitionId, scope)
+	if err := consistency.WaitForCreate(ctx, d, consistency.DefaultOptions(), roleDefinitionEventualConsistencyCreate(ctx, client, scope, roleDefinitionId)); err != nil {
+		return fmt.Errorf("waiting for Role Definition %q (Scope %q) to settle down: %+v", roleDefinitionId, scope, err)
+	}
+
 	read, err := client.Get(ctx, scope, roleDefinitionId)
 	if err != nil {
 		return err
@@ -209,7 +270,14 @@ func resourceArmRoleDefinitionUpdate(d *schema.ResourceData, meta interface{}) e
 
 	permissionsRaw := d.Get("permissions").([]interface{})
 	permissions := expandRoleDefinitionPermissions(permissionsRaw)
-	assignableScopes := expandRoleDefinitionAssignableScopes(d)
+	permissions, err = resolveRoleDefinitionPermissions(ctx, sdkClient, d, permissions)
+	if err != nil {
+		return fmt.Errorf("resolving `inherits_from`/`excluded_permissions` for Role Definition %q (Scope %q): %+v", roleDefinitionId.RoleID, roleDefinitionId.Scope, err)
+	}
+	assignableScopes, err := expandRoleDefinitionAssignableScopes(ctx, meta, d)
+	if err != nil {
+		return fmt.Errorf("expanding `assignable_scopes` for Role Definition %q (Scope %q): %+v", roleDefinitionId.RoleID, roleDefinitionId.Scope, err)
+	}
 
 	properties := authorization.RoleDefinition{
 		RoleDefinitionProperties: &authorization.RoleDefinitionProperties{
@@ -238,16 +306,7 @@ func resourceArmRoleDefinitionUpdate(d *schema.ResourceData, meta interface{}) e
 	// but eventually switch to being the old create date and the new update date
 	// ergo we can can for the old create date and the new updated date
 	log.Printf("[DEBUG] Waiting for Role Definition %q (Scope %q) to settle down..", roleDefinitionId.RoleID, roleDefinitionId.Scope)
-	stateConf := &resource.StateChangeConf{
-		ContinuousTargetOccurence: 5,
-		Delay:                     10 * time.Second,
-		MinTimeout:                10 * time.Second,
-		Pending:                   []string{"Pending"},
-		Target:                    []string{"Updated"},
-		Refresh:                   roleDefinitionEventualConsistencyUpdate(ctx, client, *roleDefinitionId, *updatedOn),
-		Timeout:                   d.Timeout(schema.TimeoutUpdate),
-	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if err := consistency.WaitForUpdate(ctx, d, consistency.DefaultOptions(), roleDefinitionEventualConsistencyUpdate(ctx, client, *roleDefinitionId, *updatedOn)); err != nil {
 		return fmt.Errorf("waiting for Role Definition %q (Scope %q) to settle down: %+v", roleDefinitionId.RoleID, roleDefinitionId.Scope, err)
 	}
 
@@ -283,15 +342,32 @@ func resourceArmRoleDefinitionRead(d *schema.ResourceData, meta interface{}) err
 		d.Set("name", props.RoleName)
 		d.Set("description", props.Description)
 
-		permissions := flattenRoleDefinitionPermissions(props.Permissions)
-		if err := d.Set("permissions", permissions); err != nil {
-			return err
+		// when `inherits_from` or `excluded_permissions` is set, the resolved permission set sent
+		// to the API diverges from what the user authored - so `permissions` is left as-is rather
+		// than overwritten with the live (resolved) read, otherwise Azure adding actions to the
+		// inherited role - or the excluded actions - would show up as drift on every plan.
+		hasComposition := len(d.Get("inherits_from").([]interface{})) > 0 || len(d.Get("excluded_permissions").([]interface{})) > 0
+		if !hasComposition {
+			permissions := flattenRoleDefinitionPermissions(props.Permissions)
+			if err := d.Set("permissions", permissions); err != nil {
+				return err
+			}
 		}
 
 		assignableScopes := flattenRoleDefinitionAssignableScopes(props.AssignableScopes)
-		if err := d.Set("assignable_scopes", assignableScopes); err != nil {
+		if err := d.Set("assignable_scopes_resolved", assignableScopes); err != nil {
 			return err
 		}
+
+		// when wildcards are expanded, `assignable_scopes` holds the user-authored patterns rather
+		// than the resolved list Azure actually stores - leaving it alone here means a subscription
+		// being added/removed under a `managementGroups/*` pattern outside Terraform isn't reported
+		// as drift unless the pattern itself changes.
+		if !d.Get("expand_assignable_scopes").(bool) {
+			if err := d.Set("assignable_scopes", assignableScopes); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -311,34 +387,75 @@ func resourceArmRoleDefinitionDelete(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
+	// deletion propagates asynchronously - a read immediately afterwards can still find the role
+	// definition, so wait for it to actually disappear before reporting the resource gone.
+	log.Printf("[DEBUG] Waiting for Role Definition %q (Scope %q) to be deleted..", id.RoleID, id.Scope)
+	if err := consistency.WaitForDeletion(ctx, d, func() (bool, error) {
+		resp, err := client.Get(ctx, id.Scope, id.RoleID)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}); err != nil {
+		return fmt.Errorf("waiting for Role Definition %q (Scope %q) to be deleted: %+v", id.RoleID, id.Scope, err)
+	}
+
 	return nil
 }
 
-func roleDefinitionEventualConsistencyUpdate(ctx context.Context, client azuresdkhacks.RoleDefinitionsWorkaroundClient, id parse.RoleDefinitionID, expectedUpdateDate string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
+func roleDefinitionEventualConsistencyCreate(ctx context.Context, client interface {
+	Get(ctx context.Context, scope string, roleDefinitionID string) (authorization.RoleDefinition, error)
+}, scope, roleDefinitionId string) consistency.UpdateRefreshFunc {
+	return func() (bool, bool, error) {
+		resp, err := client.Get(ctx, scope, roleDefinitionId)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return false, false, nil
+			}
+			return false, false, err
+		}
+		if resp.ID == nil || *resp.ID == "" {
+			return true, false, nil
+		}
+
+		return true, true, nil
+	}
+}
+
+func roleDefinitionEventualConsistencyUpdate(ctx context.Context, client azuresdkhacks.RoleDefinitionsWorkaroundClient, id parse.RoleDefinitionID, expectedUpdateDate string) consistency.UpdateRefreshFunc {
+	return func() (bool, bool, error) {
 		resp, err := client.Get(ctx, id.Scope, id.RoleID)
 		if err != nil {
-			return resp, "Failed", err
+			return false, false, err
 		}
 		if resp.RoleDefinitionProperties == nil {
-			return resp, "Failed", fmt.Errorf("`properties` was nil")
+			return false, false, fmt.Errorf("`properties` was nil")
 		}
 		if resp.RoleDefinitionProperties.CreatedOn == nil {
-			return resp, "Failed", fmt.Errorf("`properties.CreatedOn` was nil")
+			return false, false, fmt.Errorf("`properties.CreatedOn` was nil")
+		}
+		if resp.RoleDefinitionProperties.UpdatedOn == nil {
+			// seen immediately after a replacement role definition is created - `updatedOn` hasn't
+			// propagated yet, so treat this the same as any other not-yet-stable read rather than
+			// panicking on the dereference below.
+			return true, false, nil
 		}
 
 		respCreatedOn := *resp.RoleDefinitionProperties.CreatedOn
 		respUpdatedOn := *resp.RoleDefinitionProperties.UpdatedOn
 		if respCreatedOn == expectedUpdateDate {
 			// a new role definition is created and eventually (~5s) reconciled
-			return resp, "Pending", nil
+			return true, false, nil
 		}
 		if respUpdatedOn != expectedUpdateDate {
 			// however the updatedOn should match the new date, to show this has been reconciled
-			return resp, "Pending", nil
+			return true, false, nil
 		}
 
-		return resp, "Updated", nil
+		return true, true, nil
 	}
 }
 
@@ -402,21 +519,42 @@ func expandRoleDefinitionPermissions(input []interface{}) []authorization.Permis
 	return output
 }
 
-func expandRoleDefinitionAssignableScopes(d *schema.ResourceData) []string {
+func expandRoleDefinitionAssignableScopes(ctx context.Context, meta interface{}, d *schema.ResourceData) ([]string, error) {
 	scopes := make([]string, 0)
 
 	// The first scope in the list must be the target scope as it it not returned in any API call
 	assignedScope := d.Get("scope").(string)
 	scopes = append(scopes, assignedScope)
 	assignableScopes := d.Get("assignable_scopes").([]interface{})
+
+	expand := d.Get("expand_assignable_scopes").(bool)
+
 	for _, scope := range assignableScopes {
-		// Ensure the assigned scope is not duplicated in the list if also specified in `assignable_scopes`
-		if scope != assignedScope {
-			scopes = append(scopes, scope.(string))
+		pattern := scope.(string)
+
+		resolved := []string{pattern}
+		if expand {
+			var err error
+			resolved, err = resolveAssignableScopePattern(ctx, meta, pattern)
+			if err != nil {
+				return nil, fmt.Errorf("resolving `assignable_scopes` pattern %q: %+v", pattern, err)
+			}
+		}
+
+		for _, s := range resolved {
+			// Ensure the assigned scope is not duplicated in the list if also specified in `assignable_scopes`
+			if s != assignedScope {
+				scopes = append(scopes, s)
+			}
 		}
 	}
 
-	return scopes
+	if expand {
+		// keep the target scope first - only the resolved scopes after it are sorted
+		scopes = append([]string{assignedScope}, dedupeAndSortScopes(scopes[1:])...)
+	}
+
+	return scopes, nil
 }
 
 func flattenRoleDefinitionPermissions(input *[]authorization.Permission) []interface{} {
@@ -0,0 +1,108 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/resourcegraph/mgmt/2021-03-01/resourcegraph"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+)
+
+const (
+	managementGroupWildcard = "/providers/Microsoft.Management/managementGroups/*"
+	subscriptionWildcard    = "subscriptions/*"
+)
+
+// resolveAssignableScopePattern turns a single `assignable_scopes` entry into the concrete list of
+// scopes it represents. Entries without a wildcard are passed through unchanged; the management
+// group and subscription wildcards are resolved via the management groups / Resource Graph clients
+// for whatever the caller currently has access to. This necessarily runs at apply time rather than
+// plan time (the provider has no plan-time hook to call out to Azure), so `assignable_scopes_resolved`
+// reflects what Resource Graph/Management Groups returned as of the last apply, not the current plan.
+func resolveAssignableScopePattern(ctx context.Context, meta interface{}, pattern string) ([]string, error) {
+	client := meta.(*clients.Client)
+
+	switch {
+	case pattern == managementGroupWildcard:
+		groups, err := client.Authorization.ManagementGroupsClient.ListComplete(ctx, "", "")
+		if err != nil {
+			return nil, fmt.Errorf("listing Management Groups: %+v", err)
+		}
+
+		scopes := make([]string, 0)
+		for groups.NotDone() {
+			group := groups.Value()
+			if group.ID != nil {
+				scopes = append(scopes, *group.ID)
+			}
+
+			if err := groups.NextWithContext(ctx); err != nil {
+				return nil, fmt.Errorf("paging Management Groups: %+v", err)
+			}
+		}
+		return scopes, nil
+
+	case pattern == subscriptionWildcard || pattern == "/"+subscriptionWildcard:
+		return resolveSubscriptionWildcard(ctx, client)
+
+	default:
+		if strings.Contains(pattern, "*") {
+			return nil, fmt.Errorf("unsupported wildcard pattern %q - only %q and %q are supported", pattern, managementGroupWildcard, subscriptionWildcard)
+		}
+		return []string{pattern}, nil
+	}
+}
+
+// resolveSubscriptionWildcard resolves `subscriptions/*` via Resource Graph rather than the
+// Subscriptions client. `Subscriptions` is deliberately left unset - Resource Graph
+// then searches every subscription the caller can access, rather than being pinned to just the
+// provider's own subscription.
+func resolveSubscriptionWildcard(ctx context.Context, client *clients.Client) ([]string, error) {
+	query := "ResourceContainers | where type == 'microsoft.resources/subscriptions' | project id"
+	request := resourcegraph.QueryRequest{
+		Query: &query,
+		Options: &resourcegraph.QueryRequestOptions{
+			ResultFormat: resourcegraph.ResultFormatObjectArray,
+		},
+	}
+
+	resp, err := client.Authorization.ResourceGraphClient.Resources(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("querying Resource Graph for subscriptions: %+v", err)
+	}
+
+	rows, ok := resp.Data.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected Resource Graph response shape: %+v", resp.Data)
+	}
+
+	scopes := make([]string, 0, len(rows))
+	for _, row := range rows {
+		fields, ok := row.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := fields["id"].(string); ok {
+			scopes = append(scopes, id)
+		}
+	}
+
+	return scopes, nil
+}
+
+func dedupeAndSortScopes(input []string) []string {
+	seen := make(map[string]struct{}, len(input))
+	output := make([]string, 0, len(input))
+	for _, scope := range input {
+		if _, ok := seen[scope]; ok {
+			continue
+		}
+		seen[scope] = struct{}{}
+		output = append(output, scope)
+	}
+
+	sort.Strings(output)
+	return output
+}
@@ -0,0 +1,31 @@
+package authorization
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDedupeAndSortScopes(t *testing.T) {
+	input := []string{
+		"/subscriptions/22222222-2222-2222-2222-222222222222",
+		"/subscriptions/11111111-1111-1111-1111-111111111111",
+		"/subscriptions/11111111-1111-1111-1111-111111111111",
+	}
+
+	got := dedupeAndSortScopes(input)
+	want := []string{
+		"/subscriptions/11111111-1111-1111-1111-111111111111",
+		"/subscriptions/22222222-2222-2222-2222-222222222222",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("dedupeAndSortScopes(%v) = %v, want %v", input, got, want)
+	}
+}
+
+func TestDedupeAndSortScopes_empty(t *testing.T) {
+	got := dedupeAndSortScopes(nil)
+	if len(got) != 0 {
+		t.Fatalf("dedupeAndSortScopes(nil) = %v, want empty", got)
+	}
+}
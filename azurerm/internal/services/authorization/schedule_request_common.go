@@ -0,0 +1,119 @@
+package authorization
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// schemaScheduleInfo returns the shared `schedule_info` block used by both the eligibility and
+// assignment schedule request resources - the PIM APIs for both accept an identical `ScheduleInfo`
+// shape. It's deliberately not ForceNew: the request object itself is write-once, but that's
+// handled by each resource's Update submitting a new `AdminUpdate` request with the changed
+// schedule, rather than by replacing the whole Terraform resource. The earlier request is not
+// cancelled.
+func schemaScheduleInfo() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"start_date_time": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IsRFC3339Time,
+				},
+
+				"expiration": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"type": {
+								Type:     schema.TypeString,
+								Required: true,
+								ValidateFunc: validation.StringInSlice([]string{
+									"AfterDuration",
+									"AfterDateTime",
+									"NoExpiration",
+								}, false),
+							},
+
+							"duration": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+
+							"end_date_time": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.IsRFC3339Time,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type scheduleInfo struct {
+	StartDateTime string
+
+	// HasExpiration is true only when the `expiration {}` block was actually supplied - the PIM
+	// API rejects an explicit but empty expiration type, so this distinguishes "not set" from the
+	// zero value of ExpirationType.
+	HasExpiration  bool
+	ExpirationType string
+	Duration       string
+	EndDateTime    string
+}
+
+func expandScheduleInfo(input []interface{}) *scheduleInfo {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	info := &scheduleInfo{
+		StartDateTime: raw["start_date_time"].(string),
+	}
+
+	expirationRaw := raw["expiration"].([]interface{})
+	if len(expirationRaw) > 0 && expirationRaw[0] != nil {
+		expiration := expirationRaw[0].(map[string]interface{})
+		info.HasExpiration = true
+		info.ExpirationType = expiration["type"].(string)
+		info.Duration = expiration["duration"].(string)
+		info.EndDateTime = expiration["end_date_time"].(string)
+	}
+
+	return info
+}
+
+func flattenScheduleInfo(input *scheduleInfo) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	expiration := []interface{}{}
+	if input.HasExpiration {
+		expiration = []interface{}{
+			map[string]interface{}{
+				"type":          input.ExpirationType,
+				"duration":      input.Duration,
+				"end_date_time": input.EndDateTime,
+			},
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"start_date_time": input.StartDateTime,
+			"expiration":      expiration,
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package authorization
+
+import "testing"
+
+func TestExpandScheduleInfo_empty(t *testing.T) {
+	if got := expandScheduleInfo(nil); got != nil {
+		t.Fatalf("expandScheduleInfo(nil) = %+v, want nil", got)
+	}
+}
+
+func TestExpandFlattenScheduleInfo_roundTrip(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{
+			"start_date_time": "2022-09-01T00:00:00Z",
+			"expiration": []interface{}{
+				map[string]interface{}{
+					"type":          "AfterDuration",
+					"duration":      "P30D",
+					"end_date_time": "",
+				},
+			},
+		},
+	}
+
+	info := expandScheduleInfo(raw)
+	if info == nil {
+		t.Fatal("expandScheduleInfo returned nil for a populated block")
+	}
+	if !info.HasExpiration {
+		t.Fatal("expected HasExpiration to be true when an expiration block is supplied")
+	}
+	if info.ExpirationType != "AfterDuration" || info.Duration != "P30D" {
+		t.Fatalf("unexpected expiration fields: %+v", info)
+	}
+
+	flattened := flattenScheduleInfo(info)
+	if len(flattened) != 1 {
+		t.Fatalf("expected a single flattened block, got %d", len(flattened))
+	}
+
+	back := flattened[0].(map[string]interface{})
+	if back["start_date_time"] != info.StartDateTime {
+		t.Fatalf("start_date_time did not round-trip: %+v", back)
+	}
+}
+
+func TestExpandScheduleInfo_noExpirationBlock(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{
+			"start_date_time": "",
+			"expiration":      []interface{}{},
+		},
+	}
+
+	info := expandScheduleInfo(raw)
+	if info == nil {
+		t.Fatal("expandScheduleInfo returned nil for a populated block")
+	}
+	if info.HasExpiration {
+		t.Fatal("expected HasExpiration to be false when no expiration block was supplied")
+	}
+
+	flattened := flattenScheduleInfo(info)
+	back := flattened[0].(map[string]interface{})
+	if expiration := back["expiration"].([]interface{}); len(expiration) != 0 {
+		t.Fatalf("expected no expiration block to flatten back to empty, got %+v", expiration)
+	}
+}
@@ -2,7 +2,6 @@ package sql
 
 import (
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/postgresql/mgmt/2017-12-01/postgresql"
@@ -17,6 +16,11 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+// resourceArmPostgreSQLAdministrator manages the single AAD administrator on a PostgreSQL Single
+// Server - the Single Server API only ever supports one administrator per server, keyed by server
+// alone. For the Flexible Server multi-administrator API, see
+// `azurerm_postgresql_flexible_server_active_directory_administrator` instead - these are two
+// distinct Azure resource types and are not interchangeable.
 func resourceArmPostgreSQLAdministrator() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmPostgreSQLAdministratorCreateUpdate,
@@ -112,7 +116,7 @@ func resourceArmPostgreSQLAdministratorCreateUpdate(d *schema.ResourceData, meta
 
 	d.SetId(*resp.ID)
 
-	return nil
+	return resourceArmPostgreSQLAdministratorRead(d, meta)
 }
 
 func resourceArmPostgreSQLAdministratorRead(d *schema.ResourceData, meta interface{}) error {
@@ -125,13 +129,14 @@ func resourceArmPostgreSQLAdministratorRead(d *schema.ResourceData, meta interfa
 		return err
 	}
 
-	resourceGroup := id.ResourceGroup
-	serverName := id.Path["servers"]
+	serverName, ok := id.Path["servers"]
+	if !ok {
+		return fmt.Errorf("%q was missing a `servers` segment", d.Id())
+	}
 
-	resp, err := client.Get(ctx, resourceGroup, serverName)
+	resp, err := client.Get(ctx, id.ResourceGroup, serverName)
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
-			log.Printf("[INFO] Error reading PostgreSQL AD administrator %q - removing from state", d.Id())
 			d.SetId("")
 			return nil
 		}
@@ -139,11 +144,18 @@ func resourceArmPostgreSQLAdministratorRead(d *schema.ResourceData, meta interfa
 		return fmt.Errorf("Error reading PostgreSQL AD administrator: %+v", err)
 	}
 
-	d.Set("resource_group_name", resourceGroup)
+	d.Set("resource_group_name", id.ResourceGroup)
 	d.Set("server_name", serverName)
-	d.Set("login", resp.Login)
-	d.Set("object_id", resp.Sid.String())
-	d.Set("tenant_id", resp.TenantID.String())
+
+	if props := resp.ServerAdministratorProperties; props != nil {
+		d.Set("login", props.Login)
+		if props.Sid != nil {
+			d.Set("object_id", props.Sid.String())
+		}
+		if props.TenantID != nil {
+			d.Set("tenant_id", props.TenantID.String())
+		}
+	}
 
 	return nil
 }
@@ -158,13 +170,19 @@ func resourceArmPostgreSQLAdministratorDelete(d *schema.ResourceData, meta inter
 		return err
 	}
 
-	resourceGroup := id.ResourceGroup
-	serverName := id.Path["servers"]
+	serverName, ok := id.Path["servers"]
+	if !ok {
+		return fmt.Errorf("%q was missing a `servers` segment", d.Id())
+	}
 
-	_, err = client.Delete(ctx, resourceGroup, serverName)
+	future, err := client.Delete(ctx, id.ResourceGroup, serverName)
 	if err != nil {
 		return fmt.Errorf("Error deleting PostgreSQL AD Administrator: %+v", err)
 	}
 
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for deletion of PostgreSQL AD Administrator (Resource Group %q, Server %q): %+v", id.ResourceGroup, serverName, err)
+	}
+
 	return nil
 }
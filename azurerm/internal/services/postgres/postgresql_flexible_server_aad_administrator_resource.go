@@ -0,0 +1,259 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/postgresqlflexibleservers/mgmt/2022-12-01/postgresqlflexibleservers"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/helpers/consistency"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// resourceArmPostgreSQLFlexibleServerAdministrator manages a single AAD administrator on a
+// PostgreSQL Flexible Server. This is a distinct Azure resource type from the Single Server
+// `azurerm_postgresql_active_directory_administrator` - Flexible Server's 2022-12-01 API allows
+// more than one administrator per server, so the resource's ID is keyed by (server, object ID)
+// rather than by server alone.
+func resourceArmPostgreSQLFlexibleServerAdministrator() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmPostgreSQLFlexibleServerAdministratorCreateUpdate,
+		Read:   resourceArmPostgreSQLFlexibleServerAdministratorRead,
+		Update: resourceArmPostgreSQLFlexibleServerAdministratorCreateUpdate,
+		Delete: resourceArmPostgreSQLFlexibleServerAdministratorDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"server_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"login": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"object_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"tenant_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"principal_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(postgresqlflexibleservers.PrincipalTypeUser),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(postgresqlflexibleservers.PrincipalTypeUser),
+					string(postgresqlflexibleservers.PrincipalTypeGroup),
+					string(postgresqlflexibleservers.PrincipalTypeServicePrincipal),
+				}, false),
+			},
+		},
+	}
+}
+
+func resourceArmPostgreSQLFlexibleServerAdministratorCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).postgresql.FlexibleServerAdministratorsClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	serverName := d.Get("server_name").(string)
+	resGroup := d.Get("resource_group_name").(string)
+	login := d.Get("login").(string)
+	objectId := d.Get("object_id").(string)
+	tenantId := d.Get("tenant_id").(string)
+	principalType := d.Get("principal_type").(string)
+
+	if features.ShouldResourcesBeImported() && d.IsNewResource() {
+		existing, err := client.Get(ctx, resGroup, serverName, objectId)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing PostgreSQL Flexible Server AD Administrator (Resource Group %q, Server %q, Object ID %q): %+v", resGroup, serverName, objectId, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_postgresql_flexible_server_active_directory_administrator", *existing.ID)
+		}
+	}
+
+	parameters := postgresqlflexibleservers.ActiveDirectoryAdministratorAdd{
+		ActiveDirectoryAdministratorAddProperties: &postgresqlflexibleservers.ActiveDirectoryAdministratorAddProperties{
+			PrincipalName: utils.String(login),
+			ObjectID:      utils.String(objectId),
+			TenantID:      utils.String(tenantId),
+			PrincipalType: postgresqlflexibleservers.PrincipalType(principalType),
+		},
+	}
+
+	future, err := client.CreateOrUpdate(ctx, resGroup, serverName, objectId, parameters)
+	if err != nil {
+		return fmt.Errorf("Error issuing create/update request for PostgreSQL Flexible Server AD Administrator (Resource Group %q, Server %q): %+v", resGroup, serverName, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting on create/update future for PostgreSQL Flexible Server AD Administrator (Resource Group %q, Server %q): %+v", resGroup, serverName, err)
+	}
+
+	resp, err := client.Get(ctx, resGroup, serverName, objectId)
+	if err != nil {
+		return fmt.Errorf("Error issuing get request for PostgreSQL Flexible Server AD Administrator (Resource Group %q, Server %q): %+v", resGroup, serverName, err)
+	}
+
+	// the Administrator is provisioned asynchronously behind the `future` above, so an immediate
+	// read-back can still serve a stale `object_id`/`tenant_id` - wait until the two agree before
+	// persisting state.
+	log.Printf("[DEBUG] Waiting for PostgreSQL Flexible Server AD Administrator (Resource Group %q, Server %q) to settle down..", resGroup, serverName)
+	if err := consistency.WaitForUpdate(ctx, d, consistency.DefaultOptions(), postgreSQLFlexibleServerAdministratorEventualConsistency(ctx, client, resGroup, serverName, objectId, tenantId)); err != nil {
+		return fmt.Errorf("waiting for PostgreSQL Flexible Server AD Administrator (Resource Group %q, Server %q) to settle down: %+v", resGroup, serverName, err)
+	}
+
+	d.SetId(*resp.ID)
+
+	return nil
+}
+
+func postgreSQLFlexibleServerAdministratorEventualConsistency(ctx context.Context, client postgresqlflexibleservers.AdministratorsClient, resourceGroup, serverName, expectedObjectId, expectedTenantId string) consistency.UpdateRefreshFunc {
+	return func() (bool, bool, error) {
+		resp, err := client.Get(ctx, resourceGroup, serverName, expectedObjectId)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return false, false, nil
+			}
+			return false, false, err
+		}
+
+		if resp.ObjectID == nil || resp.TenantID == nil {
+			return true, false, nil
+		}
+
+		stable := *resp.ObjectID == expectedObjectId && *resp.TenantID == expectedTenantId
+		return true, stable, nil
+	}
+}
+
+func resourceArmPostgreSQLFlexibleServerAdministratorRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).postgresql.FlexibleServerAdministratorsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parsePostgreSQLFlexibleServerAdministratorID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.ServerName, id.ObjectId)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] Error reading PostgreSQL Flexible Server AD administrator %q - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error reading PostgreSQL Flexible Server AD administrator: %+v", err)
+	}
+
+	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("server_name", id.ServerName)
+	d.Set("login", resp.PrincipalName)
+	d.Set("object_id", resp.ObjectID)
+	d.Set("tenant_id", resp.TenantID)
+	d.Set("principal_type", string(resp.PrincipalType))
+
+	return nil
+}
+
+func resourceArmPostgreSQLFlexibleServerAdministratorDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).postgresql.FlexibleServerAdministratorsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parsePostgreSQLFlexibleServerAdministratorID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// only the administrator identified by this resource's object ID should be removed - the
+	// server can carry other `azurerm_postgresql_flexible_server_active_directory_administrator`
+	// blocks for other principals, and a server-wide delete would take those out too.
+	_, err = client.Delete(ctx, id.ResourceGroup, id.ServerName, id.ObjectId)
+	if err != nil {
+		return fmt.Errorf("Error deleting PostgreSQL Flexible Server AD Administrator: %+v", err)
+	}
+
+	if err := consistency.WaitForDeletion(ctx, d, func() (bool, error) {
+		resp, err := client.Get(ctx, id.ResourceGroup, id.ServerName, id.ObjectId)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}); err != nil {
+		return fmt.Errorf("waiting for deletion of PostgreSQL Flexible Server AD Administrator (Resource Group %q, Server %q): %+v", id.ResourceGroup, id.ServerName, err)
+	}
+
+	return nil
+}
+
+// postgreSQLFlexibleServerAdministratorId is the (server, object ID) pair that uniquely identifies
+// an administrator under the multi-admin API - the `object_id` path segment is what lets more than
+// one `azurerm_postgresql_flexible_server_active_directory_administrator` target the same server.
+type postgreSQLFlexibleServerAdministratorId struct {
+	ResourceGroup string
+	ServerName    string
+	ObjectId      string
+}
+
+func parsePostgreSQLFlexibleServerAdministratorID(input string) (*postgreSQLFlexibleServerAdministratorId, error) {
+	id, err := azure.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	serverName, ok := id.Path["flexibleServers"]
+	if !ok {
+		return nil, fmt.Errorf("ID %q is missing a `flexibleServers` segment", input)
+	}
+
+	objectId, ok := id.Path["administrators"]
+	if !ok {
+		return nil, fmt.Errorf("ID %q is missing an `administrators` segment", input)
+	}
+
+	return &postgreSQLFlexibleServerAdministratorId{
+		ResourceGroup: id.ResourceGroup,
+		ServerName:    serverName,
+		ObjectId:      objectId,
+	}, nil
+}
@@ -0,0 +1,30 @@
+package sql
+
+import "testing"
+
+func TestParsePostgreSQLFlexibleServerAdministratorID(t *testing.T) {
+	input := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforPostgreSQL/flexibleServers/server1/administrators/11111111-1111-1111-1111-111111111111"
+
+	id, err := parsePostgreSQLFlexibleServerAdministratorID(input)
+	if err != nil {
+		t.Fatalf("parsePostgreSQLFlexibleServerAdministratorID: %+v", err)
+	}
+
+	if id.ResourceGroup != "group1" {
+		t.Errorf("ResourceGroup = %q, want %q", id.ResourceGroup, "group1")
+	}
+	if id.ServerName != "server1" {
+		t.Errorf("ServerName = %q, want %q", id.ServerName, "server1")
+	}
+	if id.ObjectId != "11111111-1111-1111-1111-111111111111" {
+		t.Errorf("ObjectId = %q, want %q", id.ObjectId, "11111111-1111-1111-1111-111111111111")
+	}
+}
+
+func TestParsePostgreSQLFlexibleServerAdministratorID_missingAdministratorsSegment(t *testing.T) {
+	input := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforPostgreSQL/flexibleServers/server1"
+
+	if _, err := parsePostgreSQLFlexibleServerAdministratorID(input); err == nil {
+		t.Fatal("expected an error for an ID missing the `administrators` segment, got nil")
+	}
+}